@@ -0,0 +1,182 @@
+// Package github performs the deep permission walk for GitHub credentials.
+// Detectors are kept fast for scanning: they surface just enough context
+// (via detectors.AnalysisInfo) to identify a token, and leave enumerating
+// the orgs, repos, and teams it can reach to this out-of-band analyzer.
+package github
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/internal/githubauth"
+)
+
+// Result is everything the analyzer could enumerate with a single GitHub
+// credential. Which fields are populated depends on the credential's type:
+// a personal/OAuth token walks the orgs/repos/teams it can reach, while a
+// GitHub App or OAuth App credential only has its own identity and, for
+// Apps, the accounts it's installed on.
+type Result struct {
+	Orgs  []string
+	Repos []string
+	Teams []string
+
+	// Installations is populated for GitHub App credentials: the accounts
+	// the App is installed on.
+	Installations []string
+
+	// AppName and AppOwner are populated for GitHub App and OAuth App
+	// credentials, which identify an application rather than a user.
+	AppName  string
+	AppOwner string
+}
+
+// Analyzer performs the deep permission walk for a credential found in a
+// detector's AnalysisInfo.
+type Analyzer struct {
+	Client *http.Client
+}
+
+// New returns an Analyzer using the package's standard HTTP client.
+func New() *Analyzer {
+	return &Analyzer{Client: common.SaneHttpClient()}
+}
+
+// Analyze runs the permission walk appropriate to info's credential_type,
+// which is the same AnalysisInfo a github.Scanner attaches on verification.
+// "endpoint" is always required; the rest depends on credential_type:
+//   - "github_app": "app_id" and "private_key"
+//   - "oauth_app": "client_id" and "client_secret"
+//   - anything else (including unset, for personal/OAuth tokens): "token"
+func (a *Analyzer) Analyze(ctx context.Context, info map[string]string) (*Result, error) {
+	endpoint := info["endpoint"]
+	if endpoint == "" {
+		return nil, fmt.Errorf("github analyzer: AnalysisInfo missing endpoint")
+	}
+
+	switch info["credential_type"] {
+	case "github_app":
+		return a.analyzeApp(ctx, endpoint, info["app_id"], info["private_key"])
+	case "oauth_app":
+		return a.analyzeOAuthApp(ctx, endpoint, info["client_id"], info["client_secret"])
+	default:
+		return a.analyzeToken(ctx, endpoint, info["token"])
+	}
+}
+
+// analyzeToken enumerates the orgs, teams, and repos reachable with a
+// personal or OAuth token. Only the first page of each listing is read:
+// repos are requested 100 per page, orgs and teams use the API's default
+// page size, and later pages are not fetched.
+func (a *Analyzer) analyzeToken(ctx context.Context, endpoint, token string) (*Result, error) {
+	if token == "" {
+		return nil, fmt.Errorf("github analyzer: AnalysisInfo missing token")
+	}
+
+	var result Result
+
+	var orgs []struct {
+		Login string `json:"login"`
+	}
+	if err := githubauth.GetJSON(ctx, a.Client, fmt.Sprintf("%s/user/orgs", endpoint), fmt.Sprintf("token %s", token), &orgs); err == nil {
+		for _, org := range orgs {
+			result.Orgs = append(result.Orgs, org.Login)
+
+			var teams []struct {
+				Name string `json:"name"`
+			}
+			if err := githubauth.GetJSON(ctx, a.Client, fmt.Sprintf("%s/orgs/%s/teams", endpoint, org.Login), fmt.Sprintf("token %s", token), &teams); err == nil {
+				for _, team := range teams {
+					result.Teams = append(result.Teams, fmt.Sprintf("%s/%s", org.Login, team.Name))
+				}
+			}
+		}
+	}
+
+	var repos []struct {
+		FullName string `json:"full_name"`
+	}
+	if err := githubauth.GetJSON(ctx, a.Client, fmt.Sprintf("%s/user/repos?per_page=100", endpoint), fmt.Sprintf("token %s", token), &repos); err == nil {
+		for _, repo := range repos {
+			result.Repos = append(result.Repos, repo.FullName)
+		}
+	}
+
+	return &result, nil
+}
+
+// analyzeApp mints a fresh App JWT from the private key and identifies the
+// App and the accounts it's installed on.
+func (a *Analyzer) analyzeApp(ctx context.Context, endpoint, appID, privateKeyPEM string) (*Result, error) {
+	if appID == "" || privateKeyPEM == "" {
+		return nil, fmt.Errorf("github analyzer: AnalysisInfo missing app_id or private_key")
+	}
+
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("github analyzer: could not decode App private_key PEM")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtToken, err := githubauth.MintAppJWT(appID, key)
+	if err != nil {
+		return nil, err
+	}
+	auth := fmt.Sprintf("Bearer %s", jwtToken)
+
+	var result Result
+
+	var app struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	}
+	if err := githubauth.GetJSON(ctx, a.Client, fmt.Sprintf("%s/app", endpoint), auth, &app); err == nil {
+		result.AppName = app.Name
+		result.AppOwner = app.Owner.Login
+	}
+
+	var installations []struct {
+		Account struct {
+			Login string `json:"login"`
+		} `json:"account"`
+	}
+	if err := githubauth.GetJSON(ctx, a.Client, fmt.Sprintf("%s/app/installations", endpoint), auth, &installations); err == nil {
+		for _, inst := range installations {
+			result.Installations = append(result.Installations, inst.Account.Login)
+		}
+	}
+
+	return &result, nil
+}
+
+// analyzeOAuthApp identifies the OAuth App a client_id/client_secret pair
+// belongs to.
+func (a *Analyzer) analyzeOAuthApp(ctx context.Context, endpoint, clientID, clientSecret string) (*Result, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("github analyzer: AnalysisInfo missing client_id or client_secret")
+	}
+
+	var result Result
+
+	var app struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	}
+	if err := githubauth.GetJSONBasicAuth(ctx, a.Client, fmt.Sprintf("%s/applications/%s", endpoint, clientID), clientID, clientSecret, &app); err == nil {
+		result.AppName = app.Name
+		result.AppOwner = app.Owner.Login
+	}
+
+	return &result, nil
+}
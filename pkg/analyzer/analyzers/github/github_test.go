@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	_, appKeyPEM := generateTestAppKey(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"login":"acme"}]`))
+	})
+	mux.HandleFunc("/orgs/acme/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name":"platform"}]`))
+	})
+	mux.HandleFunc("/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"full_name":"acme/widgets"}]`))
+	})
+	mux.HandleFunc("/app", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"my-app","owner":{"login":"acme"}}`))
+	})
+	mux.HandleFunc("/app/installations", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"account":{"login":"acme"}}]`))
+	})
+	mux.HandleFunc("/applications/client123", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"my-oauth-app","owner":{"login":"acme"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	a := &Analyzer{Client: srv.Client()}
+
+	tests := []struct {
+		name    string
+		info    map[string]string
+		check   func(t *testing.T, result *Result)
+		wantErr bool
+	}{
+		{
+			name: "personal token",
+			info: map[string]string{
+				"endpoint": srv.URL,
+				"token":    "tok",
+			},
+			check: func(t *testing.T, result *Result) {
+				if len(result.Orgs) != 1 || result.Orgs[0] != "acme" {
+					t.Errorf("Orgs = %v, want [acme]", result.Orgs)
+				}
+				if len(result.Teams) != 1 || result.Teams[0] != "acme/platform" {
+					t.Errorf("Teams = %v, want [acme/platform]", result.Teams)
+				}
+				if len(result.Repos) != 1 || result.Repos[0] != "acme/widgets" {
+					t.Errorf("Repos = %v, want [acme/widgets]", result.Repos)
+				}
+			},
+		},
+		{
+			name: "github app",
+			info: map[string]string{
+				"endpoint":        srv.URL,
+				"credential_type": "github_app",
+				"app_id":          "1234",
+				"private_key":     appKeyPEM,
+			},
+			check: func(t *testing.T, result *Result) {
+				if result.AppName != "my-app" || result.AppOwner != "acme" {
+					t.Errorf("AppName/AppOwner = %q/%q, want my-app/acme", result.AppName, result.AppOwner)
+				}
+				if len(result.Installations) != 1 || result.Installations[0] != "acme" {
+					t.Errorf("Installations = %v, want [acme]", result.Installations)
+				}
+			},
+		},
+		{
+			name: "oauth app",
+			info: map[string]string{
+				"endpoint":        srv.URL,
+				"credential_type": "oauth_app",
+				"client_id":       "client123",
+				"client_secret":   "secret",
+			},
+			check: func(t *testing.T, result *Result) {
+				if result.AppName != "my-oauth-app" || result.AppOwner != "acme" {
+					t.Errorf("AppName/AppOwner = %q/%q, want my-oauth-app/acme", result.AppName, result.AppOwner)
+				}
+			},
+		},
+		{
+			name:    "missing endpoint",
+			info:    map[string]string{"token": "tok"},
+			wantErr: true,
+		},
+		{
+			name:    "github app missing private key",
+			info:    map[string]string{"endpoint": srv.URL, "credential_type": "github_app", "app_id": "1234"},
+			wantErr: true,
+		},
+		{
+			name:    "oauth app missing client secret",
+			info:    map[string]string{"endpoint": srv.URL, "credential_type": "oauth_app", "client_id": "client123"},
+			wantErr: true,
+		},
+		{
+			name:    "token missing",
+			info:    map[string]string{"endpoint": srv.URL},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := a.Analyze(context.Background(), tt.info)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Analyze() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Analyze() error = %v, want nil", err)
+			}
+			tt.check(t, result)
+		})
+	}
+}
+
+func generateTestAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("failed to generate test RSA key: %v", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return key, string(pem.EncodeToMemory(block))
+}
@@ -0,0 +1,182 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+var (
+	// GitHub OAuth App client IDs come in two formats: the newer "Iv1."
+	// prefix, which is self-identifying, and the legacy 20-character hex
+	// string, which isn't distinguishable from any other hex blob and so is
+	// only matched right after a "client_id"-style keyword.
+	// https://developer.github.com/v3/#oauth2-keysecret
+	oauthClientIDPat        = regexp.MustCompile(`\b(Iv1\.[a-f0-9]{16})\b`)
+	oauthClientIDContextPat = regexp.MustCompile(`(?i)client[_-]?id["'\s:=]+([a-f0-9]{20})\b`)
+
+	// Client secrets are 40-character hex strings, same shape as an ordinary
+	// SHA-1/commit hash, so they're only matched right after a
+	// "client_secret"-style keyword rather than trusted on their own.
+	oauthClientSecretContextPat = regexp.MustCompile(`(?i)client[_-]?secret["'\s:=]+([a-f0-9]{40})\b`)
+
+	// oauthProximityWindow bounds how far apart a client ID and secret can be
+	// and still be considered a pair, to avoid matching unrelated hex
+	// strings elsewhere in a large chunk.
+	oauthProximityWindow = 500
+)
+
+// groupMatches returns the [start, end) byte ranges of pat's first capture
+// group across all non-overlapping matches in s.
+func groupMatches(pat *regexp.Regexp, s string) [][]int {
+	var locs [][]int
+	for _, m := range pat.FindAllStringSubmatchIndex(s, -1) {
+		locs = append(locs, []int{m[2], m[3]})
+	}
+	return locs
+}
+
+type oauthAppRes struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	CallbackURL string `json:"callback_url"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// verifyOAuthClientPair confirms a client_id/client_secret pair by asking
+// GitHub to check a (deliberately bogus) access token for them. GitHub
+// rejects unknown client credentials with 401 before it ever looks at the
+// token, so a 404 here means the pair itself was accepted.
+// https://docs.github.com/en/rest/apps/oauth-applications#check-a-token
+func verifyOAuthClientPair(ctx context.Context, client *http.Client, baseURL, clientID, clientSecret string) (bool, error) {
+	body, err := json.Marshal(map[string]string{"access_token": "0000000000000000000000000000000000000000"})
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/applications/%s/token", baseURL, clientID), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.SetBasicAuth(clientID, clientSecret)
+	req.Header.Add("Content-Type", "application/json; charset=utf-8")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return true, nil
+	case http.StatusUnauthorized:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d from %s", res.StatusCode, req.URL)
+	}
+}
+
+// gapBetween returns the number of bytes separating two non-overlapping
+// ranges, or 0 if they overlap.
+func gapBetween(aStart, aEnd, bStart, bEnd int) int {
+	if aEnd <= bStart {
+		return bStart - aEnd
+	}
+	if bEnd <= aStart {
+		return aStart - bEnd
+	}
+	return 0
+}
+
+// fromOAuthPairs finds GitHub OAuth App client_id/client_secret pairs by
+// pairing each client ID with its nearest client secret in the same chunk,
+// since the two are separate strings rather than a single token.
+func (s Scanner) fromOAuthPairs(ctx context.Context, verify bool, dataStr string, urls []string) (results []detectors.Result) {
+	idMatches := append(groupMatches(oauthClientIDPat, dataStr), groupMatches(oauthClientIDContextPat, dataStr)...)
+	secretMatches := groupMatches(oauthClientSecretContextPat, dataStr)
+	if len(idMatches) == 0 || len(secretMatches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, idLoc := range idMatches {
+		clientID := dataStr[idLoc[0]:idLoc[1]]
+
+		bestSecret := ""
+		bestGap := -1
+		for _, secretLoc := range secretMatches {
+			gap := gapBetween(idLoc[0], idLoc[1], secretLoc[0], secretLoc[1])
+			if gap > oauthProximityWindow {
+				continue
+			}
+			if bestGap == -1 || gap < bestGap {
+				bestGap = gap
+				bestSecret = dataStr[secretLoc[0]:secretLoc[1]]
+			}
+		}
+		if bestSecret == "" {
+			continue
+		}
+
+		pairKey := clientID + ":" + bestSecret
+		if seen[pairKey] {
+			continue
+		}
+		seen[pairKey] = true
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_Github,
+			Raw:          []byte(clientID),
+			RawV2:        []byte(pairKey),
+		}
+
+		if verify {
+			client := common.SaneHttpClient()
+			for _, url := range urls {
+				valid, err := verifyOAuthClientPair(ctx, client, url, clientID, bestSecret)
+				if err != nil || !valid {
+					continue
+				}
+
+				s1.Verified = true
+				s1.ExtraData = map[string]string{
+					"token_type": "oauth_app_credentials",
+					"client_id":  clientID,
+				}
+
+				var app oauthAppRes
+				if err := getJSONBasicAuth(ctx, client, fmt.Sprintf("%s/applications/%s", url, clientID), clientID, bestSecret, &app); err == nil {
+					s1.ExtraData["app_name"] = app.Name
+					s1.ExtraData["app_owner"] = app.Owner.Login
+					s1.ExtraData["callback_url"] = app.CallbackURL
+				}
+
+				s1.AnalysisInfo = map[string]string{
+					"credential_type": "oauth_app",
+					"endpoint":        url,
+					"client_id":       clientID,
+					"client_secret":   bestSecret,
+				}
+				break
+			}
+		}
+
+		if !s1.Verified && detectors.IsKnownFalsePositive(string(s1.Raw), detectors.DefaultFalsePositives, true) {
+			continue
+		}
+
+		results = append(results, s1)
+	}
+
+	return
+}
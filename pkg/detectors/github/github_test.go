@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClassifyTokenType(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"ghp_abc123", "classic_pat"},
+		{"gho_abc123", "oauth"},
+		{"ghu_abc123", "user_to_server"},
+		{"ghs_abc123", "server_to_server"},
+		{"ghr_abc123", "refresh"},
+		{"github_pat_abc123", "fine_grained_pat"},
+		{"not_a_github_token", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := classifyTokenType(tt.token); got != tt.want {
+			t.Errorf("classifyTokenType(%q) = %q, want %q", tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverVerifierURLs(t *testing.T) {
+	data := "found near ghp_abc123: host ghe.github.example.com, path build.internal/api/v3, remote git@sshhost.example.com:org/repo.git"
+
+	t.Run("disabled by default", func(t *testing.T) {
+		s := Scanner{}
+		if got := s.discoverVerifierURLs(data); got != nil {
+			t.Errorf("discoverVerifierURLs() with discovery disabled = %v, want nil", got)
+		}
+	})
+
+	t.Run("opted in via WithVerifierURLDiscovery", func(t *testing.T) {
+		s := New(WithVerifierURLDiscovery(nil))
+		got := s.discoverVerifierURLs(data)
+		want := map[string]bool{
+			"https://ghe.github.example.com/api/v3": true,
+			"https://build.internal/api/v3":         true,
+			"https://sshhost.example.com/api/v3":    true,
+		}
+		if len(got) != len(want) {
+			t.Fatalf("discoverVerifierURLs() = %v, want %d entries matching %v", got, len(want), want)
+		}
+		for _, url := range got {
+			if !want[url] {
+				t.Errorf("discoverVerifierURLs() produced unexpected url %q", url)
+			}
+		}
+	})
+}
+
+func TestEnrichOrgMemberships(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"login":"acme"},{"login":"other"}]`))
+	})
+	mux.HandleFunc("/user/memberships/orgs/acme", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"role":"admin"}`))
+	})
+	mux.HandleFunc("/orgs/acme/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name":"platform"},{"name":"security"}]`))
+	})
+	mux.HandleFunc("/user/memberships/orgs/other", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/orgs/other/teams", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := Scanner{}
+	got := s.enrichOrgMemberships(context.Background(), srv.Client(), srv.URL, "token")
+	want := "acme(admin):platform|security, other"
+	if got != want {
+		t.Errorf("enrichOrgMemberships() = %q, want %q", got, want)
+	}
+}
+
+func TestEnrichOrgMembershipsAllowlist(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"login":"acme"},{"login":"other"}]`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := Scanner{orgAllowlist: []string{"other"}}
+	got := s.enrichOrgMemberships(context.Background(), srv.Client(), srv.URL, "token")
+	if got != "other" {
+		t.Errorf("enrichOrgMemberships() with allowlist = %q, want %q", got, "other")
+	}
+}
+
+func TestFromOAuthPairs(t *testing.T) {
+	s := New()
+	clientID := strings.Repeat("ab", 10)     // 20 hex chars
+	clientSecret := strings.Repeat("cd", 20) // 40 hex chars
+
+	t.Run("bare hex without keyword context is ignored", func(t *testing.T) {
+		data := clientID + " and " + clientSecret
+		results := s.fromOAuthPairs(context.Background(), false, data, nil)
+		if len(results) != 0 {
+			t.Errorf("fromOAuthPairs() with no keyword context = %d results, want 0", len(results))
+		}
+	})
+
+	t.Run("keyword-anchored pair is matched", func(t *testing.T) {
+		data := "client_id: " + clientID + "\nclient_secret: " + clientSecret
+		results := s.fromOAuthPairs(context.Background(), false, data, nil)
+		if len(results) != 1 {
+			t.Fatalf("fromOAuthPairs() = %d results, want 1", len(results))
+		}
+		if string(results[0].Raw) != clientID {
+			t.Errorf("fromOAuthPairs() Raw = %q, want %q", string(results[0].Raw), clientID)
+		}
+	})
+
+	t.Run("Iv1 client id needs no keyword", func(t *testing.T) {
+		data := "Iv1.0123456789abcdef and client_secret: " + clientSecret
+		results := s.fromOAuthPairs(context.Background(), false, data, nil)
+		if len(results) != 1 {
+			t.Fatalf("fromOAuthPairs() = %d results, want 1", len(results))
+		}
+	})
+}
+
+func TestFromAppKeys(t *testing.T) {
+	_, pem1 := generateTestAppKey(t)
+	_, pem2 := generateTestAppKey(t)
+
+	data := "app_id: 1111\n" + pem1 + "\napp_id: 2222\n" + pem2
+
+	s := New()
+	results := s.fromAppKeys(context.Background(), false, data, nil)
+	if len(results) != 2 {
+		t.Fatalf("fromAppKeys() = %d results, want 2", len(results))
+	}
+}
+
+func generateTestAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatalf("failed to generate test RSA key: %v", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return key, string(pem.EncodeToMemory(block))
+}
@@ -0,0 +1,71 @@
+// Package githubauth holds the GitHub authentication plumbing shared by the
+// github detector and its out-of-band analyzer: minting the short-lived JWT
+// a GitHub App uses to identify itself, and the authenticated JSON GET used
+// to call the GitHub API either side of that boundary. AnalysisInfo carries
+// App private keys and OAuth App secrets across that boundary so the
+// analyzer can re-derive credentials the detector already minted once; a fix
+// to clock skew, claim shape, or status handling here applies to both.
+package githubauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// MintAppJWT builds the short-lived RS256 JWT GitHub Apps use to
+// authenticate as themselves (as opposed to as an installation).
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/generating-a-json-web-token-jwt-for-a-github-app
+func MintAppJWT(appID string, key *rsa.PrivateKey) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-30 * time.Second)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    appID,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+}
+
+// GetJSON performs an authenticated GET and decodes the JSON response body
+// into out. authHeader is sent verbatim as the Authorization header, e.g.
+// "token <pat>" or "Bearer <jwt>".
+func GetJSON(ctx context.Context, client *http.Client, url, authHeader string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", authHeader)
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// GetJSONBasicAuth is GetJSON for callers that authenticate with HTTP basic
+// auth, e.g. an OAuth App's client_id/client_secret pair.
+func GetJSONBasicAuth(ctx context.Context, client *http.Client, url, username, password string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(username, password)
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}